@@ -0,0 +1,138 @@
+/*
+Copyright 2018 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command controller runs the stream-spike controller manager: the Stream,
+// Broker and Subscription reconcilers hosted on a single controller-runtime
+// Manager.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	istiov1alpha2 "github.com/scothis/stream-spike/pkg/apis/config.istio.io/v1alpha2"
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+	spikev1alpha2 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha2"
+	"github.com/scothis/stream-spike/pkg/broker"
+	"github.com/scothis/stream-spike/pkg/brokercount"
+	"github.com/scothis/stream-spike/pkg/stream"
+	"github.com/scothis/stream-spike/pkg/subscription"
+	"github.com/scothis/stream-spike/pkg/webhook"
+)
+
+// leaderElectionID identifies this controller's leader election lock, so
+// that the Stream, Broker and Subscription controllers across every replica
+// of a scaled deployment coordinate on the same lock rather than each
+// electing its own leader.
+const leaderElectionID = "stream-spike-controller-leader-election"
+
+func main() {
+	var metricsAddr string
+	var defaultExposure string
+	var namespace string
+	var webhookServiceDNSName string
+	var brokerCountSource string
+	var enableLeaderElection bool
+	var healthProbeAddr string
+	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "the address the metrics endpoint binds to")
+	flag.StringVar(&defaultExposure, "default-exposure", string(spikev1alpha1.StreamExposureNodePort),
+		"the StreamBackend used for Streams that don't set spec.exposure")
+	flag.StringVar(&namespace, "namespace", "default", "the namespace the controller manager runs in")
+	flag.StringVar(&webhookServiceDNSName, "webhook-service-dns-name", "stream-spike-webhook.default.svc",
+		"the in-cluster DNS name the webhook server's serving cert is issued for")
+	flag.StringVar(&brokerCountSource, "broker-count-source", brokercount.SourceLeases,
+		fmt.Sprintf("where the Stream controller counts live backend broker instances from, %q or %q", brokercount.SourceStatic, brokercount.SourceLeases))
+	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
+		"enable leader election, so only one replica of a scaled deployment reconciles at a time")
+	flag.StringVar(&healthProbeAddr, "health-probe-bind-addr", ":8081", "the address the health and readiness probe endpoints bind to")
+	flag.Parse()
+
+	scheme := clientgoscheme.Scheme
+	if err := spikev1alpha1.AddToScheme(scheme); err != nil {
+		glog.Fatalf("unable to add spike.local/v1alpha1 to scheme: %v", err)
+	}
+	if err := spikev1alpha2.AddToScheme(scheme); err != nil {
+		glog.Fatalf("unable to add spike.local/v1alpha2 to scheme: %v", err)
+	}
+	if err := istiov1alpha2.AddToScheme(scheme); err != nil {
+		glog.Fatalf("unable to add config.istio.io/v1alpha2 to scheme: %v", err)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       leaderElectionID,
+		HealthProbeBindAddress: healthProbeAddr,
+	})
+	if err != nil {
+		glog.Fatalf("unable to start manager: %v", err)
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		glog.Fatalf("unable to set up health check: %v", err)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		glog.Fatalf("unable to set up ready check: %v", err)
+	}
+
+	if err := webhook.SetupWithManager(mgr, namespace, webhookServiceDNSName, spikev1alpha1.StreamExposure(defaultExposure)); err != nil {
+		glog.Fatalf("unable to set up webhook server: %v", err)
+	}
+
+	brokerCount, err := brokercount.New(brokerCountSource, mgr.GetClient(), brokercount.DefaultTTL)
+	if err != nil {
+		glog.Fatalf("unable to create broker count source: %v", err)
+	}
+
+	streamReconciler := &stream.Reconciler{
+		Client:          mgr.GetClient(),
+		Recorder:        mgr.GetEventRecorderFor("stream-controller"),
+		DefaultExposure: spikev1alpha1.StreamExposure(defaultExposure),
+		BrokerCount:     brokerCount,
+	}
+	if err := streamReconciler.SetupWithManager(mgr); err != nil {
+		glog.Fatalf("unable to create stream controller: %v", err)
+	}
+
+	brokerReconciler := &broker.Reconciler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("broker-controller"),
+	}
+	if err := brokerReconciler.SetupWithManager(mgr); err != nil {
+		glog.Fatalf("unable to create broker controller: %v", err)
+	}
+
+	subscriptionReconciler := &subscription.Reconciler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor("subscription-controller"),
+	}
+	if err := subscriptionReconciler.SetupWithManager(mgr); err != nil {
+		glog.Fatalf("unable to create subscription controller: %v", err)
+	}
+
+	glog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		glog.Errorf("problem running manager: %v", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2018 the original author or authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RouteRule is the minimal slice of Istio's config.istio.io/v1alpha2
+// RouteRule CRD that stream-spike needs: routing a Service's traffic to a
+// single destination.
+type RouteRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RouteRuleSpec `json:"spec"`
+}
+
+// RouteRuleSpec is the spec for a RouteRule resource.
+type RouteRuleSpec struct {
+	// Destination is where traffic should be routed to: a Service name, or a
+	// Subscriber URI for a Subscription's dispatcher route.
+	Destination string `json:"destination"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RouteRuleList is a list of RouteRule resources.
+type RouteRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RouteRule `json:"items"`
+}
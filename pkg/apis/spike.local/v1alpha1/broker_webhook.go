@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// defaultBrokerReplicas is the replica count the mutating webhook fills in
+// when spec.replicas is unset, matching the fallback the Broker reconciler
+// applies itself at Deployment-creation time.
+const defaultBrokerReplicas int32 = 1
+
+// SetupWebhookWithManager registers the validating and mutating webhooks for
+// Broker with mgr.
+func (b *Broker) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(b).
+		Complete()
+}
+
+var _ webhook.Defaulter = &Broker{}
+
+// Default fills in spec.replicas when unset.
+func (b *Broker) Default() {
+	if b.Spec.Replicas == nil {
+		replicas := defaultBrokerReplicas
+		b.Spec.Replicas = &replicas
+	}
+}
+
+var _ webhook.Validator = &Broker{}
+
+// ValidateCreate rejects a Broker specifying zero replicas; a Broker with no
+// backend instances can never become Ready.
+func (b *Broker) ValidateCreate() (admission.Warnings, error) {
+	return nil, b.validateReplicas()
+}
+
+// ValidateUpdate rejects an update that sets spec.replicas to zero.
+func (b *Broker) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	return nil, b.validateReplicas()
+}
+
+// ValidateDelete allows all deletes.
+func (b *Broker) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (b *Broker) validateReplicas() error {
+	if b.Spec.Replicas != nil && *b.Spec.Replicas == 0 {
+		return fmt.Errorf("spec.replicas must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Hub marks Stream as the conversion hub for the spike.local group: other
+// versions (e.g. v1alpha2) implement conversion.Convertible against it
+// rather than against each other.
+func (*Stream) Hub() {}
+
+// Hub marks Broker as the conversion hub for the spike.local group.
+func (*Broker) Hub() {}
+
+// Hub marks Subscription as the conversion hub for the spike.local group.
+func (*Subscription) Hub() {}
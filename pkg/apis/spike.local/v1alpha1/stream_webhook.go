@@ -0,0 +1,96 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// streamReader is used by ValidateCreate/ValidateUpdate to confirm the
+// Broker a Stream references exists. It's populated by SetupWebhookWithManager
+// since admission.Validator methods aren't otherwise handed a client.
+var streamReader client.Reader
+
+// defaultStreamExposure is the exposure mutation defaults to when a Stream
+// doesn't set spec.exposure. SetupWebhookWithManager sets it to the same
+// value passed to the controller's --default-exposure flag, so the webhook
+// default and Reconciler.DefaultExposure never disagree; the mutating
+// webhook runs on every create/update, so Reconcile itself never actually
+// sees an empty spec.exposure to fall back on.
+var defaultStreamExposure = StreamExposureNodePort
+
+// SetupWebhookWithManager registers the validating and mutating webhooks for
+// Stream with mgr, defaulting spec.exposure to defaultExposure when unset.
+func (s *Stream) SetupWebhookWithManager(mgr ctrl.Manager, defaultExposure StreamExposure) error {
+	streamReader = mgr.GetAPIReader()
+	defaultStreamExposure = defaultExposure
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(s).
+		Complete()
+}
+
+var _ webhook.Defaulter = &Stream{}
+
+// Default sets spec.exposure to the package default when unset.
+func (s *Stream) Default() {
+	if s.Spec.Exposure == "" {
+		s.Spec.Exposure = defaultStreamExposure
+	}
+}
+
+var _ webhook.Validator = &Stream{}
+
+// ValidateCreate rejects a Stream that references a Broker that doesn't
+// exist in its namespace.
+func (s *Stream) ValidateCreate() (admission.Warnings, error) {
+	return nil, s.validateBrokerExists()
+}
+
+// ValidateUpdate rejects an update that leaves the Stream referencing a
+// Broker that doesn't exist in its namespace.
+func (s *Stream) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	return nil, s.validateBrokerExists()
+}
+
+// ValidateDelete allows all deletes; a Stream's Broker reference is never
+// load-bearing for removing the Stream itself.
+func (s *Stream) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (s *Stream) validateBrokerExists() error {
+	if s.Spec.Broker == "" || streamReader == nil {
+		return nil
+	}
+	broker := &Broker{}
+	key := client.ObjectKey{Namespace: s.Namespace, Name: s.Spec.Broker}
+	if err := streamReader.Get(context.Background(), key, broker); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("spec.broker %q does not exist in namespace %q", s.Spec.Broker, s.Namespace)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the validating and mutating webhooks for
+// Subscription with mgr.
+func (s *Subscription) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(s).
+		Complete()
+}
+
+var _ webhook.Defaulter = &Subscription{}
+
+// Default is a no-op; Subscription has nothing to default today. It exists
+// so Subscription satisfies webhook.Defaulter alongside Stream and Broker.
+func (s *Subscription) Default() {}
+
+var _ webhook.Validator = &Subscription{}
+
+// ValidateCreate rejects a Subscription whose spec.subscriber isn't a
+// parseable absolute URI; the dispatcher can't route to anything less.
+func (s *Subscription) ValidateCreate() (admission.Warnings, error) {
+	return nil, s.validateSubscriber()
+}
+
+// ValidateUpdate rejects an update that leaves spec.subscriber unparseable.
+func (s *Subscription) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	return nil, s.validateSubscriber()
+}
+
+// ValidateDelete allows all deletes.
+func (s *Subscription) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (s *Subscription) validateSubscriber() error {
+	u, err := url.ParseRequestURI(s.Spec.Subscriber)
+	if err != nil {
+		return fmt.Errorf("spec.subscriber %q is not a valid URI: %v", s.Spec.Subscriber, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("spec.subscriber %q must be an absolute URI with a scheme and host", s.Spec.Subscriber)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Stream is a specification for a Stream resource
+type Stream struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StreamSpec   `json:"spec"`
+	Status StreamStatus `json:"status"`
+}
+
+// StreamSpec is the spec for a Stream resource
+type StreamSpec struct {
+	// Broker is the name of the Broker resource this Stream is backed by.
+	Broker string `json:"broker,omitempty"`
+	// Exposure selects how the Stream is made reachable from outside the
+	// cluster. When empty, the controller's configured default is used.
+	// +optional
+	Exposure StreamExposure `json:"exposure,omitempty"`
+}
+
+// StreamExposure selects the backend used to make a Stream's Service
+// reachable from outside the cluster.
+type StreamExposure string
+
+const (
+	// StreamExposureNodePort exposes the Stream via a NodePort Service.
+	StreamExposureNodePort StreamExposure = "NodePort"
+	// StreamExposureLoadBalancer exposes the Stream via a LoadBalancer Service.
+	StreamExposureLoadBalancer StreamExposure = "LoadBalancer"
+	// StreamExposureIngress exposes the Stream via a ClusterIP Service
+	// fronted by an Ingress.
+	StreamExposureIngress StreamExposure = "Ingress"
+	// StreamExposureIstio exposes the Stream via a ClusterIP Service
+	// fronted by an Istio RouteRule.
+	StreamExposureIstio StreamExposure = "Istio"
+)
+
+// StreamConditionType represents the different conditions a Stream's status
+// can report.
+type StreamConditionType string
+
+const (
+	// StreamConditionReady is set when the Stream is fully reconciled and
+	// able to serve traffic.
+	StreamConditionReady StreamConditionType = "Ready"
+	// StreamConditionServiceProvisioned is set once the Service backing the
+	// Stream has been created.
+	StreamConditionServiceProvisioned StreamConditionType = "ServiceProvisioned"
+	// StreamConditionBackendReady is set once the Broker backing the Stream
+	// reports a healthy messaging backend.
+	StreamConditionBackendReady StreamConditionType = "BackendReady"
+)
+
+// StreamCondition describes the state of a Stream at a point in time.
+type StreamCondition struct {
+	// Type of Stream condition.
+	Type StreamConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition transitioned from
+	// one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a one-word CamelCase reason for the condition's last
+	// transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about the last
+	// transition.
+	Message string `json:"message,omitempty"`
+}
+
+// StreamStatus is the status for a Stream resource
+type StreamStatus struct {
+	// Conditions is the set of conditions the reconciler has observed for
+	// this Stream.
+	// +optional
+	Conditions []StreamCondition `json:"conditions,omitempty"`
+	// URL is the externally reachable address of the Stream, as computed by
+	// the exposure backend selected for it.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// GetCondition returns the condition of the given type, or nil if it has not
+// been set.
+func (ss *StreamStatus) GetCondition(t StreamConditionType) *StreamCondition {
+	for i := range ss.Conditions {
+		if ss.Conditions[i].Type == t {
+			return &ss.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates the condition of the given type, updating
+// LastTransitionTime only when the status actually changes.
+func (ss *StreamStatus) SetCondition(t StreamConditionType, status corev1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	if existing := ss.GetCondition(t); existing != nil {
+		if existing.Status != status {
+			existing.LastTransitionTime = now
+		}
+		existing.Status = status
+		existing.Reason = reason
+		existing.Message = message
+		return
+	}
+	ss.Conditions = append(ss.Conditions, StreamCondition{
+		Type:               t,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StreamList is a list of Stream resources
+type StreamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Stream `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Broker is a specification for a Broker resource. It provisions the
+// messaging backend that Streams are carried over.
+type Broker struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BrokerSpec   `json:"spec"`
+	Status BrokerStatus `json:"status"`
+}
+
+// BrokerSpec is the spec for a Broker resource
+type BrokerSpec struct {
+	// Replicas is the desired number of messaging backend instances.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Image is the messaging backend image to run (e.g. Kafka, NATS).
+	Image string `json:"image"`
+}
+
+// BrokerStatus is the status for a Broker resource
+type BrokerStatus struct {
+	// Conditions is the set of conditions the reconciler has observed for
+	// this Broker.
+	// +optional
+	Conditions []StreamCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BrokerList is a list of Broker resources
+type BrokerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Broker `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Subscription is a specification for a Subscription resource. It wires a
+// Stream to a Subscriber URI.
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubscriptionSpec   `json:"spec"`
+	Status SubscriptionStatus `json:"status"`
+}
+
+// SubscriptionSpec is the spec for a Subscription resource
+type SubscriptionSpec struct {
+	// Stream is the name of the Stream resource being subscribed to.
+	Stream string `json:"stream"`
+	// Subscriber is the URI messages are dispatched to.
+	Subscriber string `json:"subscriber"`
+}
+
+// SubscriptionStatus is the status for a Subscription resource
+type SubscriptionStatus struct {
+	// Conditions is the set of conditions the reconciler has observed for
+	// this Subscription.
+	// +optional
+	Conditions []StreamCondition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SubscriptionList is a list of Subscription resources
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Subscription `json:"items"`
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBrokerDefault(t *testing.T) {
+	b := &Broker{}
+	b.Default()
+	if b.Spec.Replicas == nil || *b.Spec.Replicas != defaultBrokerReplicas {
+		t.Fatalf("Default() left Spec.Replicas = %v, want %d", b.Spec.Replicas, defaultBrokerReplicas)
+	}
+
+	var explicit int32 = 3
+	b = &Broker{Spec: BrokerSpec{Replicas: &explicit}}
+	b.Default()
+	if *b.Spec.Replicas != 3 {
+		t.Fatalf("Default() overwrote an explicit Spec.Replicas, got %d", *b.Spec.Replicas)
+	}
+}
+
+func TestBrokerValidateReplicas(t *testing.T) {
+	var zero int32
+	b := &Broker{Spec: BrokerSpec{Replicas: &zero}}
+	if _, err := b.ValidateCreate(); err == nil {
+		t.Error("expected ValidateCreate to reject spec.replicas = 0")
+	}
+
+	var one int32 = 1
+	b = &Broker{Spec: BrokerSpec{Replicas: &one}}
+	if _, err := b.ValidateCreate(); err != nil {
+		t.Errorf("expected ValidateCreate to accept spec.replicas = 1, got %v", err)
+	}
+}
+
+func TestStreamDefault(t *testing.T) {
+	defer func(prev StreamExposure) { defaultStreamExposure = prev }(defaultStreamExposure)
+	defaultStreamExposure = StreamExposureLoadBalancer
+
+	s := &Stream{}
+	s.Default()
+	if s.Spec.Exposure != StreamExposureLoadBalancer {
+		t.Fatalf("Default() left Spec.Exposure = %q, want %q", s.Spec.Exposure, StreamExposureLoadBalancer)
+	}
+
+	s = &Stream{Spec: StreamSpec{Exposure: StreamExposureIstio}}
+	s.Default()
+	if s.Spec.Exposure != StreamExposureIstio {
+		t.Fatalf("Default() overwrote an explicit Spec.Exposure, got %q", s.Spec.Exposure)
+	}
+}
+
+func TestStreamValidateBrokerExists(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme(clientgo): %v", err)
+	}
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme(spikev1alpha1): %v", err)
+	}
+
+	broker := &Broker{ObjectMeta: metav1.ObjectMeta{Name: "b1", Namespace: "default"}}
+	c := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(broker).Build()
+
+	prevReader := streamReader
+	streamReader = c
+	defer func() { streamReader = prevReader }()
+
+	s := &Stream{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}, Spec: StreamSpec{Broker: "b1"}}
+	if _, err := s.ValidateCreate(); err != nil {
+		t.Errorf("expected ValidateCreate to accept a Stream referencing an existing Broker, got %v", err)
+	}
+
+	s = &Stream{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}, Spec: StreamSpec{Broker: "missing"}}
+	if _, err := s.ValidateCreate(); err == nil {
+		t.Error("expected ValidateCreate to reject a Stream referencing a nonexistent Broker")
+	}
+}
+
+func TestSubscriptionValidateSubscriber(t *testing.T) {
+	cases := []struct {
+		subscriber string
+		wantErr    bool
+	}{
+		{"http://example.com", false},
+		{"https://example.com/path", false},
+		{"not-a-uri", true},
+		{"/just-a-path", true},
+	}
+	for _, c := range cases {
+		s := &Subscription{Spec: SubscriptionSpec{Subscriber: c.subscriber}}
+		_, err := s.ValidateCreate()
+		if (err != nil) != c.wantErr {
+			t.Errorf("ValidateCreate() for subscriber %q: err = %v, wantErr %v", c.subscriber, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+)
+
+func convertConditionsToHub(in []StreamCondition) []spikev1alpha1.StreamCondition {
+	if in == nil {
+		return nil
+	}
+	out := make([]spikev1alpha1.StreamCondition, len(in))
+	for i := range in {
+		out[i] = spikev1alpha1.StreamCondition{
+			Type:               spikev1alpha1.StreamConditionType(in[i].Type),
+			Status:             in[i].Status,
+			LastTransitionTime: in[i].LastTransitionTime,
+			Reason:             in[i].Reason,
+			Message:            in[i].Message,
+		}
+	}
+	return out
+}
+
+func convertConditionsFromHub(in []spikev1alpha1.StreamCondition) []StreamCondition {
+	if in == nil {
+		return nil
+	}
+	out := make([]StreamCondition, len(in))
+	for i := range in {
+		out[i] = StreamCondition{
+			Type:               StreamConditionType(in[i].Type),
+			Status:             in[i].Status,
+			LastTransitionTime: in[i].LastTransitionTime,
+			Reason:             in[i].Reason,
+			Message:            in[i].Message,
+		}
+	}
+	return out
+}
+
+// ConvertTo converts s to the v1alpha1 hub type.
+func (s *Stream) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*spikev1alpha1.Stream)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.Stream, got %T", dstRaw)
+	}
+	dst.ObjectMeta = s.ObjectMeta
+	dst.Spec = spikev1alpha1.StreamSpec{
+		Broker:   s.Spec.Broker,
+		Exposure: spikev1alpha1.StreamExposure(s.Spec.Exposure),
+	}
+	dst.Status = spikev1alpha1.StreamStatus{
+		Conditions: convertConditionsToHub(s.Status.Conditions),
+		URL:        s.Status.URL,
+	}
+	return nil
+}
+
+// ConvertFrom populates s from the v1alpha1 hub type.
+func (s *Stream) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*spikev1alpha1.Stream)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.Stream, got %T", srcRaw)
+	}
+	s.ObjectMeta = src.ObjectMeta
+	s.Spec = StreamSpec{
+		Broker:   src.Spec.Broker,
+		Exposure: StreamExposure(src.Spec.Exposure),
+	}
+	s.Status = StreamStatus{
+		Conditions: convertConditionsFromHub(src.Status.Conditions),
+		URL:        src.Status.URL,
+	}
+	return nil
+}
+
+// ConvertTo converts b to the v1alpha1 hub type, renaming WorkloadImage back
+// to Image.
+func (b *Broker) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*spikev1alpha1.Broker)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.Broker, got %T", dstRaw)
+	}
+	dst.ObjectMeta = b.ObjectMeta
+	dst.Spec = spikev1alpha1.BrokerSpec{
+		Replicas: b.Spec.Replicas,
+		Image:    b.Spec.WorkloadImage,
+	}
+	dst.Status = spikev1alpha1.BrokerStatus{
+		Conditions: convertConditionsToHub(b.Status.Conditions),
+	}
+	return nil
+}
+
+// ConvertFrom populates b from the v1alpha1 hub type, renaming Image to
+// WorkloadImage.
+func (b *Broker) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*spikev1alpha1.Broker)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.Broker, got %T", srcRaw)
+	}
+	b.ObjectMeta = src.ObjectMeta
+	b.Spec = BrokerSpec{
+		Replicas:      src.Spec.Replicas,
+		WorkloadImage: src.Spec.Image,
+	}
+	b.Status = BrokerStatus{
+		Conditions: convertConditionsFromHub(src.Status.Conditions),
+	}
+	return nil
+}
+
+// ConvertTo converts s to the v1alpha1 hub type.
+func (s *Subscription) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*spikev1alpha1.Subscription)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.Subscription, got %T", dstRaw)
+	}
+	dst.ObjectMeta = s.ObjectMeta
+	dst.Spec = spikev1alpha1.SubscriptionSpec{
+		Stream:     s.Spec.Stream,
+		Subscriber: s.Spec.Subscriber,
+	}
+	dst.Status = spikev1alpha1.SubscriptionStatus{
+		Conditions: convertConditionsToHub(s.Status.Conditions),
+	}
+	return nil
+}
+
+// ConvertFrom populates s from the v1alpha1 hub type.
+func (s *Subscription) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*spikev1alpha1.Subscription)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha1.Subscription, got %T", srcRaw)
+	}
+	s.ObjectMeta = src.ObjectMeta
+	s.Spec = SubscriptionSpec{
+		Stream:     src.Spec.Stream,
+		Subscriber: src.Spec.Subscriber,
+	}
+	s.Status = SubscriptionStatus{
+		Conditions: convertConditionsFromHub(src.Status.Conditions),
+	}
+	return nil
+}
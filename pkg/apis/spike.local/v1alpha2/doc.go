@@ -0,0 +1,24 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +k8s:deepcopy-gen=package,register
+
+// Package v1alpha2 is the v1alpha2 version of the spike.local API group.
+// v1alpha1 remains the storage/conversion hub; types here convert to and
+// from it via a conversion webhook so field renames can roll out without
+// breaking objects already persisted as v1alpha1.
+// +groupName=spike.local
+package v1alpha2 // import "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha2"
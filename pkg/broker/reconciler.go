@@ -0,0 +1,191 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/scothis/stream-spike/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+)
+
+const controllerAgentName = "broker-controller"
+
+const (
+	// SuccessSynced is used as part of the Event 'reason' when a Broker is synced
+	SuccessSynced = "Synced"
+	// ErrResourceExists is used as part of the Event 'reason' when a Broker fails
+	// to sync due to a Deployment or Service of the same name already existing.
+	ErrResourceExists = "ErrResourceExists"
+
+	// MessageResourceExists is the message used for Events when a resource
+	// fails to sync due to a Deployment/Service already existing
+	MessageResourceExists = "Resource %q already exists and is not managed by Broker"
+	// MessageResourceSynced is the message used for an Event fired when a Broker
+	// is synced successfully
+	MessageResourceSynced = "Broker synced successfully"
+)
+
+// Reconciler implements controller-runtime's reconcile.Reconciler for Broker
+// resources. It provisions the backing messaging Deployment and Service for
+// a Broker; dependent Streams are notified of Broker changes by the Stream
+// Reconciler's own Broker watch rather than from here.
+type Reconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// SetupWithManager registers the Reconciler with mgr. It owns the Deployment
+// and Service it provisions for each Broker.
+func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
+	return controller.NewControllerManagedBy(mgr).
+		Named(controllerAgentName).
+		For(&spikev1alpha1.Broker{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Complete(r)
+}
+
+// Reconcile compares the actual state with the desired, and attempts to
+// converge the two by provisioning the backend Deployment and Service for a
+// Broker.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	broker := &spikev1alpha1.Broker{}
+	if err := r.Client.Get(ctx, req.NamespacedName, broker); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	brokerCopy := broker.DeepCopy()
+
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: brokerCopy.Namespace, Name: BrokerDeploymentName(brokerCopy.Name)}
+	err := r.Client.Get(ctx, deploymentKey, deployment)
+	if apierrors.IsNotFound(err) {
+		deployment = newBrokerDeployment(brokerCopy)
+		err = r.Client.Create(ctx, deployment)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !metav1.IsControlledBy(deployment, brokerCopy) {
+		msg := fmt.Sprintf(MessageResourceExists, deployment.Name)
+		r.Recorder.Event(brokerCopy, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return reconcile.Result{}, fmt.Errorf(msg)
+	}
+
+	service := &corev1.Service{}
+	serviceKey := client.ObjectKey{Namespace: brokerCopy.Namespace, Name: BrokerServiceName(brokerCopy.Name)}
+	err = r.Client.Get(ctx, serviceKey, service)
+	if apierrors.IsNotFound(err) {
+		service = newBrokerService(brokerCopy)
+		err = r.Client.Create(ctx, service)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !metav1.IsControlledBy(service, brokerCopy) {
+		msg := fmt.Sprintf(MessageResourceExists, service.Name)
+		r.Recorder.Event(brokerCopy, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return reconcile.Result{}, fmt.Errorf(msg)
+	}
+
+	r.Recorder.Event(brokerCopy, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return reconcile.Result{}, nil
+}
+
+func brokerOwnerRef(broker *spikev1alpha1.Broker) metav1.OwnerReference {
+	return *metav1.NewControllerRef(broker, schema.GroupVersionKind{
+		Group:   spikev1alpha1.SchemeGroupVersion.Group,
+		Version: spikev1alpha1.SchemeGroupVersion.Version,
+		Kind:    "Broker",
+	})
+}
+
+// newBrokerDeployment creates the Deployment that runs the messaging backend
+// (e.g. Kafka/NATS) for a Broker resource.
+func newBrokerDeployment(broker *spikev1alpha1.Broker) *appsv1.Deployment {
+	labels := map[string]string{
+		"broker": broker.Name,
+	}
+	replicas := broker.Spec.Replicas
+	if replicas == nil {
+		var defaultReplicas int32 = 1
+		replicas = &defaultReplicas
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            BrokerDeploymentName(broker.Name),
+			Namespace:       broker.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{brokerOwnerRef(broker)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "backend",
+							Image: broker.Spec.Image,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newBrokerService creates the Service that fronts the messaging backend
+// Deployment for a Broker resource.
+func newBrokerService(broker *spikev1alpha1.Broker) *corev1.Service {
+	labels := map[string]string{
+		"broker": broker.Name,
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            BrokerServiceName(broker.Name),
+			Namespace:       broker.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{brokerOwnerRef(broker)},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Port: 9092},
+			},
+		},
+	}
+}
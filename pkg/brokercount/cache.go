@@ -0,0 +1,64 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokercount
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is the cached count for a single (namespace, brokerName) pair.
+type cacheEntry struct {
+	count       int
+	lastRefresh time.Time
+}
+
+// CachedCounter wraps another Counter, serving Count from a cached value
+// until TTL has elapsed since the last refresh. This keeps a per-reconcile
+// call to Count from hammering the apiserver with a Lease list on every
+// Stream reconciliation. Entries are keyed by (namespace, brokerName) so
+// that refreshing one Broker's count can never serve another Broker's stale
+// value.
+type CachedCounter struct {
+	Counter Counter
+	TTL     time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+var _ Counter = &CachedCounter{}
+
+// Count returns the cached count for namespace/brokerName if it was
+// refreshed within TTL, otherwise it calls the wrapped Counter, updates the
+// cache, and returns the fresh value.
+func (c *CachedCounter) Count(namespace, brokerName string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := namespace + "/" + brokerName
+	if entry, ok := c.entries[key]; ok && time.Since(entry.lastRefresh) <= c.TTL {
+		return entry.count
+	}
+
+	count := c.Counter.Count(namespace, brokerName)
+	if c.entries == nil {
+		c.entries = map[string]cacheEntry{}
+	}
+	c.entries[key] = cacheEntry{count: count, lastRefresh: time.Now()}
+	return count
+}
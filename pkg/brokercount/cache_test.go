@@ -0,0 +1,78 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokercount
+
+import (
+	"testing"
+	"time"
+)
+
+// countingCounter counts how many times it's asked for a count and returns a
+// caller-supplied count per brokerName.
+type countingCounter struct {
+	counts map[string]int
+	calls  int
+}
+
+func (c *countingCounter) Count(namespace, brokerName string) int {
+	c.calls++
+	return c.counts[brokerName]
+}
+
+func TestCachedCounterKeyedByBrokerName(t *testing.T) {
+	inner := &countingCounter{counts: map[string]int{"broker-a": 1, "broker-b": 2}}
+	cached := &CachedCounter{Counter: inner, TTL: time.Minute}
+
+	if got := cached.Count("ns", "broker-a"); got != 1 {
+		t.Fatalf("Count(broker-a) = %d, want 1", got)
+	}
+	if got := cached.Count("ns", "broker-b"); got != 2 {
+		t.Fatalf("Count(broker-b) = %d, want 2 (must not be served broker-a's cached value)", got)
+	}
+	if inner.calls != 2 {
+		t.Fatalf("expected 2 calls to the wrapped Counter, got %d", inner.calls)
+	}
+
+	// Both should now be served from cache without another call.
+	cached.Count("ns", "broker-a")
+	cached.Count("ns", "broker-b")
+	if inner.calls != 2 {
+		t.Fatalf("expected cached reads to avoid calling the wrapped Counter, got %d calls", inner.calls)
+	}
+}
+
+func TestCachedCounterKeyedByNamespace(t *testing.T) {
+	inner := &countingCounter{counts: map[string]int{"broker": 1}}
+	cached := &CachedCounter{Counter: inner, TTL: time.Minute}
+
+	cached.Count("ns-a", "broker")
+	cached.Count("ns-b", "broker")
+	if inner.calls != 2 {
+		t.Fatalf("expected a separate cache entry per namespace, got %d calls", inner.calls)
+	}
+}
+
+func TestCachedCounterExpiresAfterTTL(t *testing.T) {
+	inner := &countingCounter{counts: map[string]int{"broker": 1}}
+	cached := &CachedCounter{Counter: inner, TTL: 0}
+
+	cached.Count("ns", "broker")
+	cached.Count("ns", "broker")
+	if inner.calls != 2 {
+		t.Fatalf("expected a zero TTL to always refresh, got %d calls", inner.calls)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokercount tracks how many backend broker instances are live for
+// a given Broker resource, so the Stream reconciler can gate readiness and
+// (eventually) shard partitions across them.
+package brokercount
+
+// Counter returns the number of live backend instances for the named
+// Broker in namespace.
+type Counter interface {
+	Count(namespace, brokerName string) int
+}
+
+// StaticCounter always reports Value, regardless of the Broker asked about.
+// It's for tests that don't care about live lease state.
+type StaticCounter struct {
+	Value int
+}
+
+var _ Counter = StaticCounter{}
+
+// Count returns c.Value.
+func (c StaticCounter) Count(namespace, brokerName string) int {
+	return c.Value
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokercount
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SourceStatic selects StaticCounter, wrapped in a fixed value of 1. It's
+	// useful for running the controller without the Lease-renewal machinery
+	// the backend broker Deployments are expected to carry.
+	SourceStatic = "static"
+	// SourceLeases selects LeaseCounter, the production source of truth.
+	SourceLeases = "leases"
+
+	// DefaultTTL is the cache TTL the controller binary passes to New.
+	DefaultTTL = 5 * time.Second
+)
+
+// New builds the Counter selected by source, wrapped in a CachedCounter with
+// the given ttl. source is one of SourceStatic or SourceLeases. The returned
+// Counter is cluster-wide: namespace is supplied per-call to Count, not
+// fixed here, since Streams and Brokers are reconciled across all
+// namespaces.
+func New(source string, c client.Client, ttl time.Duration) (Counter, error) {
+	var counter Counter
+	switch source {
+	case SourceStatic:
+		counter = StaticCounter{Value: 1}
+	case SourceLeases:
+		counter = &LeaseCounter{Client: c}
+	default:
+		return nil, fmt.Errorf("unknown broker count source %q: must be %q or %q", source, SourceStatic, SourceLeases)
+	}
+	return &CachedCounter{Counter: counter, TTL: ttl}, nil
+}
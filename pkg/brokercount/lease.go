@@ -0,0 +1,74 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokercount
+
+import (
+	"context"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// brokerLeaseLabelKey is set by each backend broker pod on the Lease it
+// renews, naming the Broker it belongs to.
+const brokerLeaseLabelKey = "spike.local/broker"
+
+// LeaseCounter counts the coordination.k8s.io/v1 Leases labeled for a Broker
+// that haven't expired, treating each live lease as one backend instance.
+// Namespace is taken per-call rather than fixed at construction, since
+// Streams and Brokers are reconciled cluster-wide, not scoped to a single
+// configured namespace.
+type LeaseCounter struct {
+	Client client.Client
+}
+
+var _ Counter = &LeaseCounter{}
+
+// Count lists the Leases labeled for brokerName in namespace and returns how
+// many are still being renewed. It uses context.Background since
+// Counter.Count takes no context; callers on a tight deadline should wrap it
+// in CachedCounter.
+func (c *LeaseCounter) Count(namespace, brokerName string) int {
+	leases := &coordinationv1.LeaseList{}
+	err := c.Client.List(context.Background(), leases,
+		client.InNamespace(namespace),
+		client.MatchingLabels{brokerLeaseLabelKey: brokerName},
+	)
+	if err != nil {
+		return 0
+	}
+
+	now := time.Now()
+	count := 0
+	for i := range leases.Items {
+		if leaseIsLive(&leases.Items[i], now) {
+			count++
+		}
+	}
+	return count
+}
+
+// leaseIsLive reports whether lease was renewed within its own lease
+// duration of now.
+func leaseIsLive(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.Before(expiry)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package names centralizes the derivation of names for the child resources
+// that the stream-spike controllers provision on behalf of the Stream,
+// Broker and Subscription custom resources.
+package names
+
+import "fmt"
+
+// StreamServiceName returns the name of the Service that backs a Stream.
+func StreamServiceName(streamName string) string {
+	return fmt.Sprintf("%s-stream", streamName)
+}
+
+// BrokerDeploymentName returns the name of the Deployment that runs the
+// messaging backend for a Broker.
+func BrokerDeploymentName(brokerName string) string {
+	return fmt.Sprintf("%s-broker", brokerName)
+}
+
+// BrokerServiceName returns the name of the Service that fronts the
+// messaging backend Deployment for a Broker.
+func BrokerServiceName(brokerName string) string {
+	return fmt.Sprintf("%s-broker-svc", brokerName)
+}
+
+// SubscriptionDispatcherName returns the name of the Deployment that
+// dispatches messages from a Stream to a Subscription's Subscriber.
+func SubscriptionDispatcherName(subscriptionName string) string {
+	return fmt.Sprintf("%s-dispatcher", subscriptionName)
+}
+
+// SubscriptionRouteRuleName returns the name of the Istio RouteRule that
+// directs traffic from the dispatcher to a Subscription's Subscriber.
+func SubscriptionRouteRuleName(subscriptionName string) string {
+	return fmt.Sprintf("%s-dispatcher-route", subscriptionName)
+}
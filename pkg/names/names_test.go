@@ -0,0 +1,50 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package names
+
+import "testing"
+
+func TestNamesAreUniquePerKind(t *testing.T) {
+	const broker = "b1"
+	if got, other := BrokerDeploymentName(broker), BrokerServiceName(broker); got == other {
+		t.Errorf("BrokerDeploymentName and BrokerServiceName must not collide, both returned %q", got)
+	}
+
+	const subscription = "s1"
+	if got, other := SubscriptionDispatcherName(subscription), SubscriptionRouteRuleName(subscription); got == other {
+		t.Errorf("SubscriptionDispatcherName and SubscriptionRouteRuleName must not collide, both returned %q", got)
+	}
+}
+
+func TestNames(t *testing.T) {
+	cases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"StreamServiceName", StreamServiceName("s1"), "s1-stream"},
+		{"BrokerDeploymentName", BrokerDeploymentName("b1"), "b1-broker"},
+		{"BrokerServiceName", BrokerServiceName("b1"), "b1-broker-svc"},
+		{"SubscriptionDispatcherName", SubscriptionDispatcherName("sub1"), "sub1-dispatcher"},
+		{"SubscriptionRouteRuleName", SubscriptionRouteRuleName("sub1"), "sub1-dispatcher-route"},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backend computes the objects and externally reachable address for
+// the Stream exposure strategies (NodePort, LoadBalancer, Ingress, Istio).
+package backend
+
+import (
+	"fmt"
+	"reflect"
+
+	. "github.com/scothis/stream-spike/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	istiov1alpha2 "github.com/scothis/stream-spike/pkg/apis/config.istio.io/v1alpha2"
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+)
+
+// Desired is the set of child objects a Backend wants to exist for a Stream.
+// Ingress and RouteRule are nil for backends that don't use them.
+type Desired struct {
+	Service   *corev1.Service
+	Ingress   *extensionsv1beta1.Ingress
+	RouteRule *istiov1alpha2.RouteRule
+}
+
+// StreamBackend computes the desired child objects for a Stream's exposure
+// strategy, and the externally reachable address once they exist.
+type StreamBackend interface {
+	// Desired returns the child objects that should exist for stream.
+	Desired(stream *spikev1alpha1.Stream) Desired
+	// URL returns the externally reachable address for stream given the
+	// actual state of its child objects, or "" if it can't yet be determined
+	// (e.g. a LoadBalancer Service still pending an external IP).
+	URL(stream *spikev1alpha1.Stream, actual Desired) string
+}
+
+// For returns the StreamBackend for the given exposure, falling back to def
+// when exposure is empty.
+func For(exposure, def spikev1alpha1.StreamExposure) StreamBackend {
+	if exposure == "" {
+		exposure = def
+	}
+	switch exposure {
+	case spikev1alpha1.StreamExposureLoadBalancer:
+		return loadBalancerBackend{}
+	case spikev1alpha1.StreamExposureIngress:
+		return ingressBackend{}
+	case spikev1alpha1.StreamExposureIstio:
+		return istioBackend{}
+	case spikev1alpha1.StreamExposureNodePort:
+		fallthrough
+	default:
+		return nodePortBackend{}
+	}
+}
+
+// ServiceNeedsUpdate reports whether actual's Service spec has drifted from
+// desired in a field the backend controls (e.g. the exposure changed the
+// Service's Type), so the reconciler knows to push an update rather than
+// leaving the stale object in place.
+func ServiceNeedsUpdate(desired, actual *corev1.Service) bool {
+	return actual.Spec.Type != desired.Spec.Type || !reflect.DeepEqual(actual.Spec.Selector, desired.Spec.Selector)
+}
+
+// IngressNeedsUpdate reports whether actual's Ingress spec has drifted from
+// desired.
+func IngressNeedsUpdate(desired, actual *extensionsv1beta1.Ingress) bool {
+	return !reflect.DeepEqual(actual.Spec, desired.Spec)
+}
+
+// RouteRuleNeedsUpdate reports whether actual's RouteRule spec has drifted
+// from desired.
+func RouteRuleNeedsUpdate(desired, actual *istiov1alpha2.RouteRule) bool {
+	return actual.Spec != desired.Spec
+}
+
+func ownerRef(stream *spikev1alpha1.Stream) metav1.OwnerReference {
+	return *metav1.NewControllerRef(stream, schema.GroupVersionKind{
+		Group:   spikev1alpha1.SchemeGroupVersion.Group,
+		Version: spikev1alpha1.SchemeGroupVersion.Version,
+		Kind:    "Stream",
+	})
+}
+
+func baseService(stream *spikev1alpha1.Stream, serviceType corev1.ServiceType) *corev1.Service {
+	labels := map[string]string{
+		"stream": stream.Name,
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            StreamServiceName(stream.Name),
+			Namespace:       stream.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{ownerRef(stream)},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{Port: 80},
+			},
+			Type: serviceType,
+		},
+	}
+}
+
+// nodePortBackend exposes the Stream via a NodePort Service.
+type nodePortBackend struct{}
+
+func (nodePortBackend) Desired(stream *spikev1alpha1.Stream) Desired {
+	return Desired{Service: baseService(stream, corev1.ServiceTypeNodePort)}
+}
+
+func (nodePortBackend) URL(stream *spikev1alpha1.Stream, actual Desired) string {
+	if actual.Service == nil || len(actual.Service.Spec.Ports) == 0 {
+		return ""
+	}
+	nodePort := actual.Service.Spec.Ports[0].NodePort
+	if nodePort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", StreamServiceName(stream.Name), nodePort)
+}
+
+// loadBalancerBackend exposes the Stream via a LoadBalancer Service.
+type loadBalancerBackend struct{}
+
+func (loadBalancerBackend) Desired(stream *spikev1alpha1.Stream) Desired {
+	return Desired{Service: baseService(stream, corev1.ServiceTypeLoadBalancer)}
+}
+
+func (loadBalancerBackend) URL(stream *spikev1alpha1.Stream, actual Desired) string {
+	if actual.Service == nil || len(actual.Service.Status.LoadBalancer.Ingress) == 0 {
+		return ""
+	}
+	lbIngress := actual.Service.Status.LoadBalancer.Ingress[0]
+	host := lbIngress.Hostname
+	if host == "" {
+		host = lbIngress.IP
+	}
+	if host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", host, actual.Service.Spec.Ports[0].Port)
+}
+
+// ingressBackend exposes the Stream via a ClusterIP Service fronted by an
+// Ingress.
+type ingressBackend struct{}
+
+func (ingressBackend) Desired(stream *spikev1alpha1.Stream) Desired {
+	service := baseService(stream, corev1.ServiceTypeClusterIP)
+	host := fmt.Sprintf("%s.%s.stream-spike.local", stream.Name, stream.Namespace)
+	ingress := &extensionsv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            StreamServiceName(stream.Name),
+			Namespace:       stream.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerRef(stream)},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+						HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+							Paths: []extensionsv1beta1.HTTPIngressPath{
+								{
+									Backend: extensionsv1beta1.IngressBackend{
+										ServiceName: service.Name,
+										ServicePort: intstr.FromInt(int(service.Spec.Ports[0].Port)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return Desired{Service: service, Ingress: ingress}
+}
+
+func (ingressBackend) URL(stream *spikev1alpha1.Stream, actual Desired) string {
+	if actual.Ingress == nil || len(actual.Ingress.Spec.Rules) == 0 {
+		return ""
+	}
+	return actual.Ingress.Spec.Rules[0].Host
+}
+
+// istioBackend exposes the Stream via a ClusterIP Service fronted by an
+// Istio RouteRule.
+type istioBackend struct{}
+
+func (istioBackend) Desired(stream *spikev1alpha1.Stream) Desired {
+	service := baseService(stream, corev1.ServiceTypeClusterIP)
+	routeRule := &istiov1alpha2.RouteRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            StreamServiceName(stream.Name),
+			Namespace:       stream.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerRef(stream)},
+		},
+		Spec: istiov1alpha2.RouteRuleSpec{
+			Destination: service.Name,
+		},
+	}
+	return Desired{Service: service, RouteRule: routeRule}
+}
+
+func (istioBackend) URL(stream *spikev1alpha1.Stream, actual Desired) string {
+	if actual.RouteRule == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s.svc.cluster.local", actual.RouteRule.Name, stream.Namespace)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+
+	istiov1alpha2 "github.com/scothis/stream-spike/pkg/apis/config.istio.io/v1alpha2"
+)
+
+func TestServiceNeedsUpdate(t *testing.T) {
+	desired := &corev1.Service{Spec: corev1.ServiceSpec{
+		Type:     corev1.ServiceTypeNodePort,
+		Selector: map[string]string{"stream": "s1"},
+	}}
+
+	same := desired.DeepCopy()
+	if ServiceNeedsUpdate(desired, same) {
+		t.Error("expected no update needed when actual matches desired")
+	}
+
+	typeDrifted := desired.DeepCopy()
+	typeDrifted.Spec.Type = corev1.ServiceTypeLoadBalancer
+	if !ServiceNeedsUpdate(desired, typeDrifted) {
+		t.Error("expected update needed when Type drifted")
+	}
+
+	selectorDrifted := desired.DeepCopy()
+	selectorDrifted.Spec.Selector = map[string]string{"stream": "other"}
+	if !ServiceNeedsUpdate(desired, selectorDrifted) {
+		t.Error("expected update needed when Selector drifted")
+	}
+}
+
+func TestIngressNeedsUpdate(t *testing.T) {
+	desired := &extensionsv1beta1.Ingress{Spec: extensionsv1beta1.IngressSpec{
+		Backend: &extensionsv1beta1.IngressBackend{ServiceName: "s1"},
+	}}
+
+	same := desired.DeepCopy()
+	if IngressNeedsUpdate(desired, same) {
+		t.Error("expected no update needed when actual matches desired")
+	}
+
+	drifted := desired.DeepCopy()
+	drifted.Spec.Backend.ServiceName = "other"
+	if !IngressNeedsUpdate(desired, drifted) {
+		t.Error("expected update needed when Spec drifted")
+	}
+}
+
+func TestRouteRuleNeedsUpdate(t *testing.T) {
+	desired := &istiov1alpha2.RouteRule{Spec: istiov1alpha2.RouteRuleSpec{Destination: "s1"}}
+
+	same := desired.DeepCopy()
+	if RouteRuleNeedsUpdate(desired, same) {
+		t.Error("expected no update needed when actual matches desired")
+	}
+
+	drifted := desired.DeepCopy()
+	drifted.Spec.Destination = "other"
+	if !RouteRuleNeedsUpdate(desired, drifted) {
+		t.Error("expected update needed when Spec drifted")
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stream
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/scothis/stream-spike/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	istiov1alpha2 "github.com/scothis/stream-spike/pkg/apis/config.istio.io/v1alpha2"
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+	"github.com/scothis/stream-spike/pkg/brokercount"
+	"github.com/scothis/stream-spike/pkg/stream/backend"
+)
+
+const controllerAgentName = "stream-controller"
+
+const (
+	// SuccessSynced is used as part of the Event 'reason' when a Stream is synced
+	SuccessSynced = "Synced"
+	// ErrResourceExists is used as part of the Event 'reason' when a Stream fails
+	// to sync due to a Service of the same name already existing.
+	ErrResourceExists = "ErrResourceExists"
+
+	// MessageResourceExists is the message used for Events when a resource
+	// fails to sync due to a Service already existing
+	MessageResourceExists = "Resource %q already exists and is not managed by Stream"
+	// MessageResourceSynced is the message used for an Event fired when a Stream
+	// is synced successfully
+	MessageResourceSynced = "Stream synced successfully"
+)
+
+// Reconciler implements controller-runtime's reconcile.Reconciler for Stream
+// resources. It is registered with a Manager by SetupWithManager, which gets
+// us leader election, metrics/healthz endpoints and cache-based clients for
+// free in exchange for giving up the hand-rolled workqueue/informer plumbing
+// the previous generation of this controller used.
+type Reconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+
+	// DefaultExposure is the StreamBackend used for Streams that don't set
+	// spec.exposure, configured at the controller level.
+	DefaultExposure spikev1alpha1.StreamExposure
+
+	// BrokerCount reports how many backend instances are live for a Broker,
+	// used to gate BackendReady. A Stream with no Broker set is always
+	// considered backend-ready, since it isn't carried by one.
+	BrokerCount brokercount.Counter
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// SetupWithManager registers the Reconciler with mgr. It owns the Service
+// (and, depending on exposure, Ingress) it provisions for each Stream, and
+// watches Brokers so that a Broker's changes re-reconcile every Stream that
+// references it.
+func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
+	return controller.NewControllerManagedBy(mgr).
+		Named(controllerAgentName).
+		For(&spikev1alpha1.Stream{}).
+		Owns(&corev1.Service{}).
+		Owns(&extensionsv1beta1.Ingress{}).
+		Watches(&source.Kind{Type: &spikev1alpha1.Broker{}}, handler.EnqueueRequestsFromMapFunc(r.streamsForBroker)).
+		Complete(r)
+}
+
+// Reconcile compares the actual state with the desired, and attempts to
+// converge the two. It then updates the Status block of the Stream resource
+// with the current state of the world.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	stream := &spikev1alpha1.Stream{}
+	if err := r.Client.Get(ctx, req.NamespacedName, stream); err != nil {
+		// The Stream resource may no longer exist, in which case we stop
+		// processing.
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	streamCopy := stream.DeepCopy()
+
+	b := backend.For(streamCopy.Spec.Exposure, r.DefaultExposure)
+	desired := b.Desired(streamCopy)
+
+	service := &corev1.Service{}
+	serviceKey := client.ObjectKey{Namespace: streamCopy.Namespace, Name: desired.Service.Name}
+	err := r.Client.Get(ctx, serviceKey, service)
+	if apierrors.IsNotFound(err) {
+		service = desired.Service
+		err = r.Client.Create(ctx, service)
+	}
+	if err != nil {
+		streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionServiceProvisioned, corev1.ConditionFalse, "ServiceError", err.Error())
+		r.updateStreamStatus(ctx, streamCopy)
+		return reconcile.Result{}, err
+	}
+
+	// If the Service is not controlled by this Stream resource, we should log
+	// a warning to the event recorder and return
+	if !metav1.IsControlledBy(service, streamCopy) {
+		msg := fmt.Sprintf(MessageResourceExists, service.Name)
+		r.Recorder.Event(streamCopy, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return reconcile.Result{}, fmt.Errorf(msg)
+	}
+
+	// The exposure backend may have changed since the Service was created
+	// (e.g. spec.exposure flipping from NodePort to Ingress); push an update
+	// rather than reporting stale state forever.
+	if backend.ServiceNeedsUpdate(desired.Service, service) {
+		service.Spec.Type = desired.Service.Spec.Type
+		service.Spec.Selector = desired.Service.Spec.Selector
+		if err := r.Client.Update(ctx, service); err != nil {
+			streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionServiceProvisioned, corev1.ConditionFalse, "ServiceError", err.Error())
+			r.updateStreamStatus(ctx, streamCopy)
+			return reconcile.Result{}, err
+		}
+	}
+
+	actual := backend.Desired{Service: service}
+
+	if desired.Ingress != nil {
+		ingress, err := r.reconcileIngress(ctx, streamCopy, desired.Ingress)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		actual.Ingress = ingress
+	}
+
+	if desired.RouteRule != nil {
+		routeRule, err := r.reconcileRouteRule(ctx, streamCopy, desired.RouteRule)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		actual.RouteRule = routeRule
+	}
+
+	streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionServiceProvisioned, corev1.ConditionTrue, "ServiceProvisioned", "")
+
+	backendReady := true
+	if streamCopy.Spec.Broker != "" && r.BrokerCount != nil {
+		if count := r.BrokerCount.Count(streamCopy.Namespace, streamCopy.Spec.Broker); count == 0 {
+			backendReady = false
+			streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionBackendReady, corev1.ConditionFalse, "NoLiveBrokers", fmt.Sprintf("Broker %q has no live backend instances", streamCopy.Spec.Broker))
+		}
+	}
+	if backendReady {
+		streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionBackendReady, corev1.ConditionTrue, "BackendReady", "")
+	}
+
+	streamCopy.Status.URL = b.URL(streamCopy, actual)
+	if backendReady {
+		streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionReady, corev1.ConditionTrue, "Ready", "")
+	} else {
+		streamCopy.Status.SetCondition(spikev1alpha1.StreamConditionReady, corev1.ConditionFalse, "NoLiveBrokers", "waiting for a live backend broker instance")
+	}
+
+	if err := r.updateStreamStatus(ctx, streamCopy); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.Recorder.Event(streamCopy, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return reconcile.Result{}, nil
+}
+
+// reconcileIngress creates the desired Ingress if it doesn't already exist,
+// updates it in place if its spec has drifted from desired (e.g. the Stream's
+// host changed), and returns the actual object.
+func (r *Reconciler) reconcileIngress(ctx context.Context, stream *spikev1alpha1.Stream, desired *extensionsv1beta1.Ingress) (*extensionsv1beta1.Ingress, error) {
+	ingress := &extensionsv1beta1.Ingress{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: stream.Namespace, Name: desired.Name}, ingress)
+	if apierrors.IsNotFound(err) {
+		return desired, r.Client.Create(ctx, desired)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(ingress, stream) {
+		msg := fmt.Sprintf(MessageResourceExists, ingress.Name)
+		r.Recorder.Event(stream, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return nil, fmt.Errorf(msg)
+	}
+	if backend.IngressNeedsUpdate(desired, ingress) {
+		ingress.Spec = desired.Spec
+		if err := r.Client.Update(ctx, ingress); err != nil {
+			return nil, err
+		}
+	}
+	return ingress, nil
+}
+
+// reconcileRouteRule creates the desired Istio RouteRule if it doesn't
+// already exist, updates it in place if its spec has drifted from desired,
+// and returns the actual object.
+func (r *Reconciler) reconcileRouteRule(ctx context.Context, stream *spikev1alpha1.Stream, desired *istiov1alpha2.RouteRule) (*istiov1alpha2.RouteRule, error) {
+	routeRule := &istiov1alpha2.RouteRule{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: stream.Namespace, Name: desired.Name}, routeRule)
+	if apierrors.IsNotFound(err) {
+		return desired, r.Client.Create(ctx, desired)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(routeRule, stream) {
+		msg := fmt.Sprintf(MessageResourceExists, routeRule.Name)
+		r.Recorder.Event(stream, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return nil, fmt.Errorf(msg)
+	}
+	if backend.RouteRuleNeedsUpdate(desired, routeRule) {
+		routeRule.Spec = desired.Spec
+		if err := r.Client.Update(ctx, routeRule); err != nil {
+			return nil, err
+		}
+	}
+	return routeRule, nil
+}
+
+// updateStreamStatus persists streamCopy's Status block. It prefers the
+// status subresource, which keeps Spec changes racing in from other clients
+// from being clobbered by a stale Status update, and falls back to a full
+// Update when the CRD was registered without the status subresource enabled.
+func (r *Reconciler) updateStreamStatus(ctx context.Context, stream *spikev1alpha1.Stream) error {
+	err := r.Client.Status().Update(ctx, stream)
+	if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+		// The status subresource is unavailable (e.g. the CRD was applied
+		// without `subresources.status`); fall back to a full Update.
+		err = r.Client.Update(ctx, stream)
+	}
+	return err
+}
+
+// streamsForBroker maps a Broker event to reconcile.Requests for every
+// Stream in its namespace that references it by name, so that a Broker's
+// changes (including deletion) re-reconcile its dependent Streams.
+func (r *Reconciler) streamsForBroker(obj client.Object) []reconcile.Request {
+	broker, ok := obj.(*spikev1alpha1.Broker)
+	if !ok {
+		return nil
+	}
+
+	streams := &spikev1alpha1.StreamList{}
+	if err := r.Client.List(context.Background(), streams, client.InNamespace(broker.Namespace)); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, stream := range streams.Items {
+		if stream.Spec.Broker == broker.Name {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&stream),
+			})
+		}
+	}
+	return requests
+}
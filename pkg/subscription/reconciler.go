@@ -0,0 +1,238 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscription
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/scothis/stream-spike/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	istiov1alpha2 "github.com/scothis/stream-spike/pkg/apis/config.istio.io/v1alpha2"
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+)
+
+const controllerAgentName = "subscription-controller"
+
+const (
+	// SuccessSynced is used as part of the Event 'reason' when a Subscription is synced
+	SuccessSynced = "Synced"
+	// ErrResourceExists is used as part of the Event 'reason' when a Subscription fails
+	// to sync due to a Deployment of the same name already existing.
+	ErrResourceExists = "ErrResourceExists"
+	// ErrStreamNotFound is used as part of the Event 'reason' when a
+	// Subscription references a Stream that doesn't exist.
+	ErrStreamNotFound = "ErrStreamNotFound"
+
+	// MessageResourceExists is the message used for Events when a resource
+	// fails to sync due to a Deployment already existing
+	MessageResourceExists = "Resource %q already exists and is not managed by Subscription"
+	// MessageResourceSynced is the message used for an Event fired when a Subscription
+	// is synced successfully
+	MessageResourceSynced = "Subscription synced successfully"
+)
+
+// Reconciler implements controller-runtime's reconcile.Reconciler for
+// Subscription resources. It wires a Stream to a Subscriber URI by
+// provisioning a dispatcher Deployment and an Istio RouteRule that routes
+// the dispatcher's traffic to the Subscriber.
+type Reconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// SetupWithManager registers the Reconciler with mgr. It owns the dispatcher
+// Deployment and RouteRule it provisions for each Subscription, and watches
+// Streams so that a Stream's changes re-reconcile every Subscription that
+// references it.
+func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
+	return controller.NewControllerManagedBy(mgr).
+		Named(controllerAgentName).
+		For(&spikev1alpha1.Subscription{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&istiov1alpha2.RouteRule{}).
+		Watches(&source.Kind{Type: &spikev1alpha1.Stream{}}, handler.EnqueueRequestsFromMapFunc(r.subscriptionsForStream)).
+		Complete(r)
+}
+
+// Reconcile compares the actual state with the desired, and attempts to
+// converge the two by provisioning the dispatcher Deployment and RouteRule
+// for a Subscription.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	subscription := &spikev1alpha1.Subscription{}
+	if err := r.Client.Get(ctx, req.NamespacedName, subscription); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	subscriptionCopy := subscription.DeepCopy()
+
+	stream := &spikev1alpha1.Stream{}
+	streamKey := client.ObjectKey{Namespace: subscriptionCopy.Namespace, Name: subscriptionCopy.Spec.Stream}
+	if err := r.Client.Get(ctx, streamKey, stream); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Don't requeue: there's nothing to do until either the
+			// Subscription is edited to reference a different Stream, or the
+			// referenced Stream is created, which re-enqueues this
+			// Subscription via subscriptionsForStream.
+			msg := fmt.Sprintf("Stream %q does not exist", subscriptionCopy.Spec.Stream)
+			r.Recorder.Event(subscriptionCopy, corev1.EventTypeWarning, ErrStreamNotFound, msg)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: subscriptionCopy.Namespace, Name: SubscriptionDispatcherName(subscriptionCopy.Name)}
+	err := r.Client.Get(ctx, deploymentKey, deployment)
+	if apierrors.IsNotFound(err) {
+		deployment = newDispatcherDeployment(subscriptionCopy, stream)
+		err = r.Client.Create(ctx, deployment)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !metav1.IsControlledBy(deployment, subscriptionCopy) {
+		msg := fmt.Sprintf(MessageResourceExists, deployment.Name)
+		r.Recorder.Event(subscriptionCopy, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return reconcile.Result{}, fmt.Errorf(msg)
+	}
+
+	routeRule := &istiov1alpha2.RouteRule{}
+	routeRuleKey := client.ObjectKey{Namespace: subscriptionCopy.Namespace, Name: SubscriptionRouteRuleName(subscriptionCopy.Name)}
+	err = r.Client.Get(ctx, routeRuleKey, routeRule)
+	if apierrors.IsNotFound(err) {
+		routeRule = newDispatcherRouteRule(subscriptionCopy)
+		err = r.Client.Create(ctx, routeRule)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !metav1.IsControlledBy(routeRule, subscriptionCopy) {
+		msg := fmt.Sprintf(MessageResourceExists, routeRule.Name)
+		r.Recorder.Event(subscriptionCopy, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return reconcile.Result{}, fmt.Errorf(msg)
+	}
+
+	r.Recorder.Event(subscriptionCopy, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	return reconcile.Result{}, nil
+}
+
+// subscriptionsForStream maps a Stream event to reconcile.Requests for every
+// Subscription in its namespace that references it by name, so that a
+// Stream's changes (including deletion) re-reconcile its dependent
+// Subscriptions.
+func (r *Reconciler) subscriptionsForStream(obj client.Object) []reconcile.Request {
+	stream, ok := obj.(*spikev1alpha1.Stream)
+	if !ok {
+		return nil
+	}
+
+	subscriptions := &spikev1alpha1.SubscriptionList{}
+	if err := r.Client.List(context.Background(), subscriptions, client.InNamespace(stream.Namespace)); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, subscription := range subscriptions.Items {
+		if subscription.Spec.Stream == stream.Name {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&subscription),
+			})
+		}
+	}
+	return requests
+}
+
+func subscriptionOwnerRef(subscription *spikev1alpha1.Subscription) metav1.OwnerReference {
+	return *metav1.NewControllerRef(subscription, schema.GroupVersionKind{
+		Group:   spikev1alpha1.SchemeGroupVersion.Group,
+		Version: spikev1alpha1.SchemeGroupVersion.Version,
+		Kind:    "Subscription",
+	})
+}
+
+// newDispatcherDeployment creates the Deployment that dispatches messages
+// from the given Stream to the Subscription's Subscriber URI.
+func newDispatcherDeployment(subscription *spikev1alpha1.Subscription, stream *spikev1alpha1.Stream) *appsv1.Deployment {
+	labels := map[string]string{
+		"subscription": subscription.Name,
+	}
+	var replicas int32 = 1
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            SubscriptionDispatcherName(subscription.Name),
+			Namespace:       subscription.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{subscriptionOwnerRef(subscription)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "dispatcher",
+							Image: "stream-spike/dispatcher",
+							Env: []corev1.EnvVar{
+								{Name: "STREAM_SERVICE", Value: StreamServiceName(stream.Name)},
+								{Name: "SUBSCRIBER_URI", Value: subscription.Spec.Subscriber},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newDispatcherRouteRule creates the Istio RouteRule that directs the
+// dispatcher's traffic to the Subscription's Subscriber URI.
+func newDispatcherRouteRule(subscription *spikev1alpha1.Subscription) *istiov1alpha2.RouteRule {
+	labels := map[string]string{
+		"subscription": subscription.Name,
+	}
+	return &istiov1alpha2.RouteRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            SubscriptionRouteRuleName(subscription.Name),
+			Namespace:       subscription.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{subscriptionOwnerRef(subscription)},
+		},
+		Spec: istiov1alpha2.RouteRuleSpec{
+			Destination: subscription.Spec.Subscriber,
+		},
+	}
+}
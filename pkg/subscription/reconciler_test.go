@@ -0,0 +1,108 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	. "github.com/scothis/stream-spike/pkg/names"
+
+	istiov1alpha2 "github.com/scothis/stream-spike/pkg/apis/config.istio.io/v1alpha2"
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme(clientgo): %v", err)
+	}
+	if err := spikev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme(spikev1alpha1): %v", err)
+	}
+	if err := istiov1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme(istiov1alpha2): %v", err)
+	}
+	return scheme
+}
+
+func TestReconcileMissingStreamDoesNotRequeue(t *testing.T) {
+	subscription := &spikev1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub1", Namespace: "default"},
+		Spec: spikev1alpha1.SubscriptionSpec{
+			Stream:     "does-not-exist",
+			Subscriber: "http://example.com",
+		},
+	}
+
+	c := fakeclient.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(subscription).Build()
+	r := &Reconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+	result, err := r.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(subscription),
+	})
+	if err != nil {
+		t.Fatalf("Reconcile returned an error for a missing Stream, want nil (no requeue): %v", err)
+	}
+	if result.Requeue || result.RequeueAfter != 0 {
+		t.Fatalf("Reconcile requested a requeue for a missing Stream, want none: %+v", result)
+	}
+}
+
+func TestReconcileCreatesDispatcherDeploymentAndRouteRule(t *testing.T) {
+	stream := &spikev1alpha1.Stream{
+		ObjectMeta: metav1.ObjectMeta{Name: "stream1", Namespace: "default"},
+	}
+	subscription := &spikev1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub1", Namespace: "default"},
+		Spec: spikev1alpha1.SubscriptionSpec{
+			Stream:     "stream1",
+			Subscriber: "http://example.com",
+		},
+	}
+
+	c := fakeclient.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(stream, subscription).Build()
+	r := &Reconciler{Client: c, Recorder: record.NewFakeRecorder(10)}
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(subscription),
+	}); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: "default", Name: SubscriptionDispatcherName(subscription.Name)}
+	if err := c.Get(context.Background(), deploymentKey, deployment); err != nil {
+		t.Fatalf("expected dispatcher Deployment to be created: %v", err)
+	}
+
+	routeRule := &istiov1alpha2.RouteRule{}
+	routeRuleKey := client.ObjectKey{Namespace: "default", Name: SubscriptionRouteRuleName(subscription.Name)}
+	if err := c.Get(context.Background(), routeRuleKey, routeRule); err != nil {
+		t.Fatalf("expected dispatcher RouteRule to be created: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+
+	// certValidity is how long a generated serving cert is valid for.
+	certValidity = 365 * 24 * time.Hour
+	// renewBefore is how far ahead of expiry CertManager rotates the cert.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// CertManager keeps the webhook server's serving certificate in CertDir in
+// sync with a self-signed cert stored in a Secret, generating and rotating
+// it as needed. Storing the cert in a Secret (rather than only on disk)
+// means every replica of the controller manager converges on the same cert
+// instead of presenting one minted independently per Pod.
+type CertManager struct {
+	Client client.Client
+	// SecretKey names the Secret the active cert/key pair is stored in.
+	SecretKey client.ObjectKey
+	// DNSName is the Subject Alternative Name the cert is issued for, e.g.
+	// the webhook Service's in-cluster DNS name.
+	DNSName string
+	// CertDir is the directory the webhook server reads tls.crt/tls.key
+	// from, per sigs.k8s.io/controller-runtime/pkg/webhook.Server.
+	CertDir string
+}
+
+// NeedLeaderElection returns false: every replica must have a valid cert on
+// disk to serve webhook requests, not only the leader.
+func (m *CertManager) NeedLeaderElection() bool {
+	return false
+}
+
+// Start ensures CertDir holds a valid cert on startup, then refreshes it
+// whenever it nears expiry until ctx is cancelled. It implements
+// manager.Runnable so it can be registered with mgr.Add.
+func (m *CertManager) Start(ctx context.Context) error {
+	if err := m.sync(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(renewBefore / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.sync(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sync writes the Secret's cert/key to CertDir, generating and persisting a
+// new self-signed pair first if the Secret is missing or expiring soon.
+func (m *CertManager) sync(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	err := m.Client.Get(ctx, m.SecretKey, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if apierrors.IsNotFound(err) || needsRotation(secret.Data[certFile]) {
+		certPEM, keyPEM, genErr := generateSelfSignedCert(m.DNSName)
+		if genErr != nil {
+			return genErr
+		}
+		if apierrors.IsNotFound(err) {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: m.SecretKey.Name, Namespace: m.SecretKey.Namespace},
+				Data:       map[string][]byte{certFile: certPEM, keyFile: keyPEM},
+			}
+			if err := m.Client.Create(ctx, secret); err != nil {
+				return err
+			}
+		} else {
+			secret.Data = map[string][]byte{certFile: certPEM, keyFile: keyPEM}
+			if err := m.Client.Update(ctx, secret); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(m.CertDir, 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.CertDir, certFile), secret.Data[certFile], 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.CertDir, keyFile), secret.Data[keyFile], 0600)
+}
+
+// needsRotation reports whether certPEM is absent, unparsable, or within
+// renewBefore of expiring.
+func needsRotation(certPEM []byte) bool {
+	if len(certPEM) == 0 {
+		return true
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	return time.Until(cert.NotAfter) < renewBefore
+}
+
+// generateSelfSignedCert mints a self-signed serving certificate for
+// dnsName, PEM-encoded.
+func generateSelfSignedCert(dnsName string) (certPEM, keyPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating cert key: %v", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating cert serial: %v", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: dnsName},
+		DNSNames:              []string{dnsName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating cert: %v", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
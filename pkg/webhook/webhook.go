@@ -0,0 +1,63 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook hosts the admission and conversion webhook server for the
+// spike.local CRDs alongside the controller manager: it registers the
+// defaulting/validating webhooks each API type implements under
+// pkg/apis/spike.local/v1alpha1, mounts the conversion webhook that
+// translates v1alpha2 objects to and from the v1alpha1 storage version, and
+// keeps the server's serving certificate rotated.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	spikev1alpha1 "github.com/scothis/stream-spike/pkg/apis/spike.local/v1alpha1"
+)
+
+// certSecretName is the Secret the webhook server's serving cert is stored
+// in; CertManager copies its cert/key into CertDir.
+const certSecretName = "stream-spike-webhook-cert"
+
+// SetupWithManager registers the Stream, Broker and Subscription admission
+// webhooks, the /convert handler for the v1alpha2 conversion webhook, and
+// the cert manager that keeps the server's TLS cert rotated, all against
+// mgr's webhook server. defaultExposure is the value the Stream mutating
+// webhook fills in for spec.exposure when unset; it should match the
+// controller's own --default-exposure flag.
+func SetupWithManager(mgr ctrl.Manager, namespace, serviceDNSName string, defaultExposure spikev1alpha1.StreamExposure) error {
+	if err := (&spikev1alpha1.Stream{}).SetupWebhookWithManager(mgr, defaultExposure); err != nil {
+		return err
+	}
+	if err := (&spikev1alpha1.Broker{}).SetupWebhookWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&spikev1alpha1.Subscription{}).SetupWebhookWithManager(mgr); err != nil {
+		return err
+	}
+
+	server := mgr.GetWebhookServer()
+	server.Register("/convert", conversion.NewWebhookHandler(mgr.GetScheme()))
+
+	return mgr.Add(&CertManager{
+		Client:    mgr.GetClient(),
+		SecretKey: client.ObjectKey{Namespace: namespace, Name: certSecretName},
+		DNSName:   serviceDNSName,
+		CertDir:   server.CertDir,
+	})
+}